@@ -0,0 +1,468 @@
+// Hand-maintained mirror of control.proto's messages. This repo does
+// not run protoc in CI, so these types -- like control_grpc.pb.go's
+// client/server stubs -- are written and kept in sync with
+// control.proto by hand. Every message gets the same Get* accessors
+// protoc-gen-go would generate, so callers can treat it the same way
+// regardless of provenance.
+
+package control
+
+import (
+	proto "github.com/golang/protobuf/proto"
+)
+
+type Empty struct{}
+
+func (m *Empty) Reset()         { *m = Empty{} }
+func (m *Empty) String() string { return "control.Empty{}" }
+func (*Empty) ProtoMessage()    {}
+
+type LcoreInfo struct {
+	Id     uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Socket uint32 `protobuf:"varint,2,opt,name=socket,proto3" json:"socket,omitempty"`
+}
+
+func (m *LcoreInfo) Reset()         { *m = LcoreInfo{} }
+func (m *LcoreInfo) String() string { return proto.CompactTextString(m) }
+func (*LcoreInfo) ProtoMessage()    {}
+
+func (m *LcoreInfo) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *LcoreInfo) GetSocket() uint32 {
+	if m != nil {
+		return m.Socket
+	}
+	return 0
+}
+
+type ListLcoresResponse struct {
+	Lcores []*LcoreInfo `protobuf:"bytes,1,rep,name=lcores,proto3" json:"lcores,omitempty"`
+}
+
+func (m *ListLcoresResponse) Reset()         { *m = ListLcoresResponse{} }
+func (m *ListLcoresResponse) String() string { return proto.CompactTextString(m) }
+func (*ListLcoresResponse) ProtoMessage()    {}
+
+func (m *ListLcoresResponse) GetLcores() []*LcoreInfo {
+	if m != nil {
+		return m.Lcores
+	}
+	return nil
+}
+
+type SubmitJobRequest struct {
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	LcoreId uint32 `protobuf:"varint,2,opt,name=lcore_id,json=lcoreId,proto3" json:"lcore_id,omitempty"`
+	Arg     []byte `protobuf:"bytes,3,opt,name=arg,proto3" json:"arg,omitempty"`
+}
+
+func (m *SubmitJobRequest) Reset()         { *m = SubmitJobRequest{} }
+func (m *SubmitJobRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitJobRequest) ProtoMessage()    {}
+
+func (m *SubmitJobRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SubmitJobRequest) GetLcoreId() uint32 {
+	if m != nil {
+		return m.LcoreId
+	}
+	return 0
+}
+
+func (m *SubmitJobRequest) GetArg() []byte {
+	if m != nil {
+		return m.Arg
+	}
+	return nil
+}
+
+type SubmitJobResponse struct {
+	Result []byte `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *SubmitJobResponse) Reset()         { *m = SubmitJobResponse{} }
+func (m *SubmitJobResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitJobResponse) ProtoMessage()    {}
+
+func (m *SubmitJobResponse) GetResult() []byte {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type PortRequest struct {
+	PortId uint32 `protobuf:"varint,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+}
+
+func (m *PortRequest) Reset()         { *m = PortRequest{} }
+func (m *PortRequest) String() string { return proto.CompactTextString(m) }
+func (*PortRequest) ProtoMessage()    {}
+
+func (m *PortRequest) GetPortId() uint32 {
+	if m != nil {
+		return m.PortId
+	}
+	return 0
+}
+
+type StatsResponse struct {
+	Ipackets uint64 `protobuf:"varint,1,opt,name=ipackets,proto3" json:"ipackets,omitempty"`
+	Opackets uint64 `protobuf:"varint,2,opt,name=opackets,proto3" json:"opackets,omitempty"`
+	Ibytes   uint64 `protobuf:"varint,3,opt,name=ibytes,proto3" json:"ibytes,omitempty"`
+	Obytes   uint64 `protobuf:"varint,4,opt,name=obytes,proto3" json:"obytes,omitempty"`
+	Imissed  uint64 `protobuf:"varint,5,opt,name=imissed,proto3" json:"imissed,omitempty"`
+	Ierrors  uint64 `protobuf:"varint,6,opt,name=ierrors,proto3" json:"ierrors,omitempty"`
+	Oerrors  uint64 `protobuf:"varint,7,opt,name=oerrors,proto3" json:"oerrors,omitempty"`
+	RxNombuf uint64 `protobuf:"varint,8,opt,name=rx_nombuf,json=rxNombuf,proto3" json:"rx_nombuf,omitempty"`
+}
+
+func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
+func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
+func (*StatsResponse) ProtoMessage()    {}
+
+func (m *StatsResponse) GetIpackets() uint64 {
+	if m != nil {
+		return m.Ipackets
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetOpackets() uint64 {
+	if m != nil {
+		return m.Opackets
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetIbytes() uint64 {
+	if m != nil {
+		return m.Ibytes
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetObytes() uint64 {
+	if m != nil {
+		return m.Obytes
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetImissed() uint64 {
+	if m != nil {
+		return m.Imissed
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetIerrors() uint64 {
+	if m != nil {
+		return m.Ierrors
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetOerrors() uint64 {
+	if m != nil {
+		return m.Oerrors
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetRxNombuf() uint64 {
+	if m != nil {
+		return m.RxNombuf
+	}
+	return 0
+}
+
+type Xstat struct {
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Value uint64 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Name  string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (m *Xstat) Reset()         { *m = Xstat{} }
+func (m *Xstat) String() string { return proto.CompactTextString(m) }
+func (*Xstat) ProtoMessage()    {}
+
+func (m *Xstat) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *Xstat) GetValue() uint64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Xstat) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type XstatsResponse struct {
+	Xstats []*Xstat `protobuf:"bytes,1,rep,name=xstats,proto3" json:"xstats,omitempty"`
+}
+
+func (m *XstatsResponse) Reset()         { *m = XstatsResponse{} }
+func (m *XstatsResponse) String() string { return proto.CompactTextString(m) }
+func (*XstatsResponse) ProtoMessage()    {}
+
+func (m *XstatsResponse) GetXstats() []*Xstat {
+	if m != nil {
+		return m.Xstats
+	}
+	return nil
+}
+
+// Ipv6Item mirrors flow.IPv6Header.
+type Ipv6Item struct {
+	VtcFlow       uint32    `protobuf:"varint,1,opt,name=vtc_flow,json=vtcFlow,proto3" json:"vtc_flow,omitempty"`
+	PayloadLength uint32    `protobuf:"varint,2,opt,name=payload_length,json=payloadLength,proto3" json:"payload_length,omitempty"`
+	Proto         uint32    `protobuf:"varint,3,opt,name=proto,proto3" json:"proto,omitempty"`
+	HopLimits     uint32    `protobuf:"varint,4,opt,name=hop_limits,json=hopLimits,proto3" json:"hop_limits,omitempty"`
+	SrcAddr       []byte    `protobuf:"bytes,5,opt,name=src_addr,json=srcAddr,proto3" json:"src_addr,omitempty"`
+	DstAddr       []byte    `protobuf:"bytes,6,opt,name=dst_addr,json=dstAddr,proto3" json:"dst_addr,omitempty"`
+	Mask          *Ipv6Item `protobuf:"bytes,7,opt,name=mask,proto3" json:"mask,omitempty"`
+}
+
+func (m *Ipv6Item) Reset()         { *m = Ipv6Item{} }
+func (m *Ipv6Item) String() string { return proto.CompactTextString(m) }
+func (*Ipv6Item) ProtoMessage()    {}
+
+func (m *Ipv6Item) GetVtcFlow() uint32 {
+	if m != nil {
+		return m.VtcFlow
+	}
+	return 0
+}
+
+func (m *Ipv6Item) GetPayloadLength() uint32 {
+	if m != nil {
+		return m.PayloadLength
+	}
+	return 0
+}
+
+func (m *Ipv6Item) GetProto() uint32 {
+	if m != nil {
+		return m.Proto
+	}
+	return 0
+}
+
+func (m *Ipv6Item) GetHopLimits() uint32 {
+	if m != nil {
+		return m.HopLimits
+	}
+	return 0
+}
+
+func (m *Ipv6Item) GetSrcAddr() []byte {
+	if m != nil {
+		return m.SrcAddr
+	}
+	return nil
+}
+
+func (m *Ipv6Item) GetDstAddr() []byte {
+	if m != nil {
+		return m.DstAddr
+	}
+	return nil
+}
+
+func (m *Ipv6Item) GetMask() *Ipv6Item {
+	if m != nil {
+		return m.Mask
+	}
+	return nil
+}
+
+// FlowItem is one element of an rte_flow pattern. More item kinds are
+// added here as the corresponding flow.Item* type gains a protobuf
+// mirror.
+type FlowItem struct {
+	// Types that are valid to be assigned to Item:
+	//	*FlowItem_Ipv6
+	Item isFlowItem_Item `protobuf_oneof:"item"`
+}
+
+func (m *FlowItem) Reset()         { *m = FlowItem{} }
+func (m *FlowItem) String() string { return proto.CompactTextString(m) }
+func (*FlowItem) ProtoMessage()    {}
+
+type isFlowItem_Item interface {
+	isFlowItem_Item()
+}
+
+type FlowItem_Ipv6 struct {
+	Ipv6 *Ipv6Item `protobuf:"bytes,1,opt,name=ipv6,proto3,oneof"`
+}
+
+func (*FlowItem_Ipv6) isFlowItem_Item() {}
+
+func (m *FlowItem) GetIpv6() *Ipv6Item {
+	if x, ok := m.GetItem().(*FlowItem_Ipv6); ok {
+		return x.Ipv6
+	}
+	return nil
+}
+
+func (m *FlowItem) GetItem() isFlowItem_Item {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+// FlowAction is one element of an rte_flow action list. Only the
+// actions needed to make InstallFlow useful end to end are covered so
+// far; more are added as callers need them.
+type FlowAction struct {
+	// Types that are valid to be assigned to Action:
+	//	*FlowAction_Drop
+	//	*FlowAction_Queue
+	Action isFlowAction_Action `protobuf_oneof:"action"`
+}
+
+func (m *FlowAction) Reset()         { *m = FlowAction{} }
+func (m *FlowAction) String() string { return proto.CompactTextString(m) }
+func (*FlowAction) ProtoMessage()    {}
+
+type isFlowAction_Action interface {
+	isFlowAction_Action()
+}
+
+type FlowAction_Drop struct {
+	Drop *FlowActionDrop `protobuf:"bytes,1,opt,name=drop,proto3,oneof"`
+}
+
+type FlowAction_Queue struct {
+	Queue *FlowActionQueue `protobuf:"bytes,2,opt,name=queue,proto3,oneof"`
+}
+
+func (*FlowAction_Drop) isFlowAction_Action()  {}
+func (*FlowAction_Queue) isFlowAction_Action() {}
+
+func (m *FlowAction) GetAction() isFlowAction_Action {
+	if m != nil {
+		return m.Action
+	}
+	return nil
+}
+
+func (m *FlowAction) GetDrop() *FlowActionDrop {
+	if x, ok := m.GetAction().(*FlowAction_Drop); ok {
+		return x.Drop
+	}
+	return nil
+}
+
+func (m *FlowAction) GetQueue() *FlowActionQueue {
+	if x, ok := m.GetAction().(*FlowAction_Queue); ok {
+		return x.Queue
+	}
+	return nil
+}
+
+type FlowActionDrop struct{}
+
+func (m *FlowActionDrop) Reset()         { *m = FlowActionDrop{} }
+func (m *FlowActionDrop) String() string { return "control.FlowActionDrop{}" }
+func (*FlowActionDrop) ProtoMessage()    {}
+
+type FlowActionQueue struct {
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (m *FlowActionQueue) Reset()         { *m = FlowActionQueue{} }
+func (m *FlowActionQueue) String() string { return proto.CompactTextString(m) }
+func (*FlowActionQueue) ProtoMessage()    {}
+
+func (m *FlowActionQueue) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type InstallFlowRequest struct {
+	PortId  uint32        `protobuf:"varint,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+	Pattern []*FlowItem   `protobuf:"bytes,2,rep,name=pattern,proto3" json:"pattern,omitempty"`
+	Actions []*FlowAction `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
+}
+
+func (m *InstallFlowRequest) Reset()         { *m = InstallFlowRequest{} }
+func (m *InstallFlowRequest) String() string { return proto.CompactTextString(m) }
+func (*InstallFlowRequest) ProtoMessage()    {}
+
+func (m *InstallFlowRequest) GetPortId() uint32 {
+	if m != nil {
+		return m.PortId
+	}
+	return 0
+}
+
+func (m *InstallFlowRequest) GetPattern() []*FlowItem {
+	if m != nil {
+		return m.Pattern
+	}
+	return nil
+}
+
+func (m *InstallFlowRequest) GetActions() []*FlowAction {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+type InstallFlowResponse struct {
+	RuleId uint64 `protobuf:"varint,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+}
+
+func (m *InstallFlowResponse) Reset()         { *m = InstallFlowResponse{} }
+func (m *InstallFlowResponse) String() string { return proto.CompactTextString(m) }
+func (*InstallFlowResponse) ProtoMessage()    {}
+
+type RemoveFlowRequest struct {
+	PortId uint32 `protobuf:"varint,1,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+	RuleId uint64 `protobuf:"varint,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+}
+
+func (m *RemoveFlowRequest) Reset()         { *m = RemoveFlowRequest{} }
+func (m *RemoveFlowRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveFlowRequest) ProtoMessage()    {}
+
+func (m *RemoveFlowRequest) GetPortId() uint32 {
+	if m != nil {
+		return m.PortId
+	}
+	return 0
+}
+
+func (m *RemoveFlowRequest) GetRuleId() uint64 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
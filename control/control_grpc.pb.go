@@ -0,0 +1,327 @@
+// Hand-maintained mirror of the client/server stubs protoc-gen-go-grpc
+// would generate from control.proto. This repo has no protoc/buf
+// generation step, so -- like control.pb.go -- this file is written
+// and kept in sync with control.proto by hand rather than produced by
+// a generator.
+
+package control
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+const (
+	Control_ListLcores_FullMethodName  = "/control.Control/ListLcores"
+	Control_SubmitJob_FullMethodName   = "/control.Control/SubmitJob"
+	Control_StartPort_FullMethodName   = "/control.Control/StartPort"
+	Control_StopPort_FullMethodName    = "/control.Control/StopPort"
+	Control_GetStats_FullMethodName    = "/control.Control/GetStats"
+	Control_GetXstats_FullMethodName   = "/control.Control/GetXstats"
+	Control_InstallFlow_FullMethodName = "/control.Control/InstallFlow"
+	Control_RemoveFlow_FullMethodName  = "/control.Control/RemoveFlow"
+	Control_Cleanup_FullMethodName     = "/control.Control/Cleanup"
+)
+
+// ControlClient is the client API for Control service.
+type ControlClient interface {
+	ListLcores(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListLcoresResponse, error)
+	SubmitJob(ctx context.Context, in *SubmitJobRequest, opts ...grpc.CallOption) (*SubmitJobResponse, error)
+	StartPort(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*Empty, error)
+	StopPort(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetStats(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	GetXstats(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*XstatsResponse, error)
+	InstallFlow(ctx context.Context, in *InstallFlowRequest, opts ...grpc.CallOption) (*InstallFlowResponse, error)
+	RemoveFlow(ctx context.Context, in *RemoveFlowRequest, opts ...grpc.CallOption) (*Empty, error)
+	Cleanup(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type controlClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewControlClient returns a ControlClient backed by cc.
+func NewControlClient(cc *grpc.ClientConn) ControlClient {
+	return &controlClient{cc}
+}
+
+func (c *controlClient) ListLcores(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListLcoresResponse, error) {
+	out := new(ListLcoresResponse)
+	if err := c.cc.Invoke(ctx, Control_ListLcores_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) SubmitJob(ctx context.Context, in *SubmitJobRequest, opts ...grpc.CallOption) (*SubmitJobResponse, error) {
+	out := new(SubmitJobResponse)
+	if err := c.cc.Invoke(ctx, Control_SubmitJob_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) StartPort(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	if err := c.cc.Invoke(ctx, Control_StartPort_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) StopPort(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	if err := c.cc.Invoke(ctx, Control_StopPort_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) GetStats(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	if err := c.cc.Invoke(ctx, Control_GetStats_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) GetXstats(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*XstatsResponse, error) {
+	out := new(XstatsResponse)
+	if err := c.cc.Invoke(ctx, Control_GetXstats_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) InstallFlow(ctx context.Context, in *InstallFlowRequest, opts ...grpc.CallOption) (*InstallFlowResponse, error) {
+	out := new(InstallFlowResponse)
+	if err := c.cc.Invoke(ctx, Control_InstallFlow_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) RemoveFlow(ctx context.Context, in *RemoveFlowRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	if err := c.cc.Invoke(ctx, Control_RemoveFlow_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Cleanup(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	if err := c.cc.Invoke(ctx, Control_Cleanup_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ControlServer is the server API for Control service. Embed
+// UnimplementedControlServer for forward compatibility with new RPCs.
+type ControlServer interface {
+	ListLcores(context.Context, *Empty) (*ListLcoresResponse, error)
+	SubmitJob(context.Context, *SubmitJobRequest) (*SubmitJobResponse, error)
+	StartPort(context.Context, *PortRequest) (*Empty, error)
+	StopPort(context.Context, *PortRequest) (*Empty, error)
+	GetStats(context.Context, *PortRequest) (*StatsResponse, error)
+	GetXstats(context.Context, *PortRequest) (*XstatsResponse, error)
+	InstallFlow(context.Context, *InstallFlowRequest) (*InstallFlowResponse, error)
+	RemoveFlow(context.Context, *RemoveFlowRequest) (*Empty, error)
+	Cleanup(context.Context, *Empty) (*Empty, error)
+}
+
+// UnimplementedControlServer may be embedded by a ControlServer
+// implementation to satisfy forward compatibility.
+type UnimplementedControlServer struct{}
+
+func (UnimplementedControlServer) ListLcores(context.Context, *Empty) (*ListLcoresResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLcores not implemented")
+}
+func (UnimplementedControlServer) SubmitJob(context.Context, *SubmitJobRequest) (*SubmitJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitJob not implemented")
+}
+func (UnimplementedControlServer) StartPort(context.Context, *PortRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartPort not implemented")
+}
+func (UnimplementedControlServer) StopPort(context.Context, *PortRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopPort not implemented")
+}
+func (UnimplementedControlServer) GetStats(context.Context, *PortRequest) (*StatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedControlServer) GetXstats(context.Context, *PortRequest) (*XstatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetXstats not implemented")
+}
+func (UnimplementedControlServer) InstallFlow(context.Context, *InstallFlowRequest) (*InstallFlowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InstallFlow not implemented")
+}
+func (UnimplementedControlServer) RemoveFlow(context.Context, *RemoveFlowRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveFlow not implemented")
+}
+func (UnimplementedControlServer) Cleanup(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method Cleanup not implemented")
+}
+
+// RegisterControlServer registers srv as the implementation backing
+// the Control service on s.
+func RegisterControlServer(s grpc.ServiceRegistrar, srv ControlServer) {
+	s.RegisterService(&Control_ServiceDesc, srv)
+}
+
+func _Control_ListLcores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ListLcores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: Control_ListLcores_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ListLcores(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_SubmitJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).SubmitJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: Control_SubmitJob_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).SubmitJob(ctx, req.(*SubmitJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_StartPort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).StartPort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: Control_StartPort_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).StartPort(ctx, req.(*PortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_StopPort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).StopPort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: Control_StopPort_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).StopPort(ctx, req.(*PortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: Control_GetStats_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).GetStats(ctx, req.(*PortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_GetXstats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).GetXstats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: Control_GetXstats_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).GetXstats(ctx, req.(*PortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_InstallFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallFlowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).InstallFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: Control_InstallFlow_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).InstallFlow(ctx, req.(*InstallFlowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_RemoveFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFlowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).RemoveFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: Control_RemoveFlow_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).RemoveFlow(ctx, req.(*RemoveFlowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Cleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Cleanup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: Control_Cleanup_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Cleanup(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Control_ServiceDesc is the grpc.ServiceDesc for the Control service.
+var Control_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "control.Control",
+	HandlerType: (*ControlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ListLcores", Handler: _Control_ListLcores_Handler},
+		{MethodName: "SubmitJob", Handler: _Control_SubmitJob_Handler},
+		{MethodName: "StartPort", Handler: _Control_StartPort_Handler},
+		{MethodName: "StopPort", Handler: _Control_StopPort_Handler},
+		{MethodName: "GetStats", Handler: _Control_GetStats_Handler},
+		{MethodName: "GetXstats", Handler: _Control_GetXstats_Handler},
+		{MethodName: "InstallFlow", Handler: _Control_InstallFlow_Handler},
+		{MethodName: "RemoveFlow", Handler: _Control_RemoveFlow_Handler},
+		{MethodName: "Cleanup", Handler: _Control_Cleanup_Handler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "control.proto",
+}
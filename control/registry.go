@@ -0,0 +1,41 @@
+package control
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/yerden/go-dpdk/eal"
+)
+
+// Job is a pre-registered unit of work that SubmitJob can dispatch to
+// an lcore by name. Arbitrary code cannot be shipped over the wire, so
+// every job a control server may run has to be registered under a
+// name at process init time via Register.
+type Job func(lc *eal.Lcore, arg []byte) ([]byte, error)
+
+var (
+	jobsMu sync.RWMutex
+	jobs   = make(map[string]Job)
+)
+
+// Register adds job under name to the process-wide job registry. It
+// panics if name is already registered: like flag redefinition or a
+// duplicate init-time table entry, that is a programming error that
+// should fail loudly at startup rather than silently pick one
+// registration over the other.
+func Register(name string, job Job) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+
+	if _, ok := jobs[name]; ok {
+		panic(fmt.Sprintf("control: job %q already registered", name))
+	}
+	jobs[name] = job
+}
+
+func lookupJob(name string) (Job, bool) {
+	jobsMu.RLock()
+	defer jobsMu.RUnlock()
+	job, ok := jobs[name]
+	return job, ok
+}
@@ -0,0 +1,284 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/yerden/go-dpdk/eal"
+	"github.com/yerden/go-dpdk/ethdev"
+	"github.com/yerden/go-dpdk/ethdev/flow"
+)
+
+// Server implements ControlServer against a running EAL/ethdev
+// application. Its zero value is ready to use.
+type Server struct {
+	UnimplementedControlServer
+
+	nextRuleID uint64
+	rulesMu    sync.Mutex
+	rules      map[uint64]*flow.Flow
+}
+
+// NewServer creates a Server ready to be registered on a grpc.Server
+// via RegisterControlServer.
+func NewServer() *Server {
+	return &Server{rules: make(map[uint64]*flow.Flow)}
+}
+
+// Serve registers srv on a new gRPC server and accepts connections on
+// lis until ctx is cancelled. lis is typically a unix socket listener,
+// the default transport for an out-of-band admin interface, but any
+// net.Listener works.
+func Serve(ctx context.Context, lis net.Listener, srv *Server, opts ...grpc.ServerOption) error {
+	gs := grpc.NewServer(opts...)
+	RegisterControlServer(gs, srv)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- gs.Serve(lis) }()
+
+	select {
+	case <-ctx.Done():
+		gs.GracefulStop()
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	}
+}
+
+// ListLcores implements ControlServer.
+func (s *Server) ListLcores(ctx context.Context, _ *Empty) (*ListLcoresResponse, error) {
+	ids := eal.Lcores(false)
+	resp := &ListLcoresResponse{Lcores: make([]*LcoreInfo, len(ids))}
+	for i, id := range ids {
+		resp.Lcores[i] = &LcoreInfo{Id: uint32(id), Socket: uint32(eal.LcoreToSocket(id))}
+	}
+	return resp, nil
+}
+
+// SubmitJob implements ControlServer, running the job registered
+// under req.Name on req.LcoreId via eal.ExecuteOnLcoreCtx.
+func (s *Server) SubmitJob(ctx context.Context, req *SubmitJobRequest) (*SubmitJobResponse, error) {
+	job, ok := lookupJob(req.GetName())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "control: job %q is not registered", req.GetName())
+	}
+
+	val, err := eal.ExecuteOnLcoreCtx(ctx, uint(req.GetLcoreId()), func(lc *eal.Lcore) (interface{}, error) {
+		return job(lc, req.GetArg())
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	result, _ := val.([]byte)
+	return &SubmitJobResponse{Result: result}, nil
+}
+
+// StartPort implements ControlServer.
+func (s *Server) StartPort(ctx context.Context, req *PortRequest) (*Empty, error) {
+	port := ethdev.PortID(req.GetPortId())
+	if _, err := eal.ExecuteOnMasterCtx(ctx, func(*eal.Lcore) (interface{}, error) {
+		return nil, port.Start()
+	}); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &Empty{}, nil
+}
+
+// StopPort implements ControlServer.
+func (s *Server) StopPort(ctx context.Context, req *PortRequest) (*Empty, error) {
+	port := ethdev.PortID(req.GetPortId())
+	if _, err := eal.ExecuteOnMasterCtx(ctx, func(*eal.Lcore) (interface{}, error) {
+		return nil, port.Stop()
+	}); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &Empty{}, nil
+}
+
+// GetStats implements ControlServer.
+func (s *Server) GetStats(ctx context.Context, req *PortRequest) (*StatsResponse, error) {
+	port := ethdev.PortID(req.GetPortId())
+
+	val, err := eal.ExecuteOnMasterCtx(ctx, func(*eal.Lcore) (interface{}, error) {
+		return port.Stats()
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	stats := val.(ethdev.EthStats)
+	return &StatsResponse{
+		Ipackets: stats.Ipackets,
+		Opackets: stats.Opackets,
+		Ibytes:   stats.Ibytes,
+		Obytes:   stats.Obytes,
+		Imissed:  stats.Imissed,
+		Ierrors:  stats.Ierrors,
+		Oerrors:  stats.Oerrors,
+		RxNombuf: stats.RxNoMbuf,
+	}, nil
+}
+
+// xstatsResult bundles the two calls GetXstats needs from a single
+// ExecuteOnMasterCtx round trip.
+type xstatsResult struct {
+	xstats []ethdev.Xstat
+	names  []string
+}
+
+// GetXstats implements ControlServer.
+func (s *Server) GetXstats(ctx context.Context, req *PortRequest) (*XstatsResponse, error) {
+	port := ethdev.PortID(req.GetPortId())
+
+	val, err := eal.ExecuteOnMasterCtx(ctx, func(*eal.Lcore) (interface{}, error) {
+		xstats, err := port.Xstats()
+		if err != nil {
+			return nil, err
+		}
+
+		names, err := ethdev.XstatNames(port)
+		if err != nil {
+			return nil, err
+		}
+
+		return xstatsResult{xstats, names}, nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := val.(xstatsResult)
+	resp := &XstatsResponse{Xstats: make([]*Xstat, len(res.xstats))}
+	for i, x := range res.xstats {
+		name := fmt.Sprintf("%d", x.Index)
+		if int(x.Index) < len(res.names) {
+			name = res.names[x.Index]
+		}
+		resp.Xstats[i] = &Xstat{Index: x.Index, Value: x.Value, Name: name}
+	}
+	return resp, nil
+}
+
+// ipv6Header converts an Ipv6Item's own fields into a flow.IPv6Header,
+// ignoring item.Mask.
+func ipv6Header(item *Ipv6Item) flow.IPv6Header {
+	header := flow.IPv6Header{
+		VtcFlow:       item.GetVtcFlow(),
+		PayloadLength: uint16(item.GetPayloadLength()),
+		Proto:         uint8(item.GetProto()),
+		HopLimits:     uint8(item.GetHopLimits()),
+	}
+	copy(header.SrcAddr[:], item.GetSrcAddr())
+	copy(header.DstAddr[:], item.GetDstAddr())
+	return header
+}
+
+// flowItems converts a protobuf FlowItem pattern into the flow package
+// types it mirrors. Today that's only ItemIPv6; more kinds are added
+// here as they gain a protobuf counterpart.
+func flowItems(pattern []*FlowItem) ([]flow.ItemStruct, error) {
+	items := make([]flow.ItemStruct, 0, len(pattern))
+	for _, item := range pattern {
+		ipv6 := item.GetIpv6()
+		if ipv6 == nil {
+			return nil, status.Error(codes.InvalidArgument, "control: flow item has no supported variant set")
+		}
+
+		match := &flow.ItemIPv6{Header: ipv6Header(ipv6)}
+		if mask := ipv6.GetMask(); mask != nil {
+			header := ipv6Header(mask)
+			match.MaskOverride = &flow.ItemIPv6Mask{Header: header}
+		}
+		items = append(items, match)
+	}
+	return items, nil
+}
+
+// flowActions converts a protobuf FlowAction list into flow package
+// actions.
+func flowActions(actions []*FlowAction) ([]flow.ActionStruct, error) {
+	out := make([]flow.ActionStruct, 0, len(actions))
+	for _, a := range actions {
+		switch {
+		case a.GetDrop() != nil:
+			out = append(out, &flow.ActionDrop{})
+		case a.GetQueue() != nil:
+			out = append(out, &flow.ActionQueue{Index: a.GetQueue().GetIndex()})
+		default:
+			return nil, status.Error(codes.InvalidArgument, "control: flow action has no supported variant set")
+		}
+	}
+	return out, nil
+}
+
+// InstallFlow implements ControlServer.
+func (s *Server) InstallFlow(ctx context.Context, req *InstallFlowRequest) (*InstallFlowResponse, error) {
+	port := ethdev.PortID(req.GetPortId())
+
+	items, err := flowItems(req.GetPattern())
+	if err != nil {
+		return nil, err
+	}
+
+	actions, err := flowActions(req.GetActions())
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := eal.ExecuteOnMasterCtx(ctx, func(*eal.Lcore) (interface{}, error) {
+		return flow.Create(port, &flow.Attr{Ingress: true}, items, actions)
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	rule := val.(*flow.Flow)
+
+	id := atomic.AddUint64(&s.nextRuleID, 1)
+	s.rulesMu.Lock()
+	if s.rules == nil {
+		s.rules = make(map[uint64]*flow.Flow)
+	}
+	s.rules[id] = rule
+	s.rulesMu.Unlock()
+
+	return &InstallFlowResponse{RuleId: id}, nil
+}
+
+// RemoveFlow implements ControlServer.
+func (s *Server) RemoveFlow(ctx context.Context, req *RemoveFlowRequest) (*Empty, error) {
+	s.rulesMu.Lock()
+	rule, ok := s.rules[req.GetRuleId()]
+	if ok {
+		delete(s.rules, req.GetRuleId())
+	}
+	s.rulesMu.Unlock()
+
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "control: no flow rule with id %d on port %d", req.GetRuleId(), req.GetPortId())
+	}
+
+	if _, err := eal.ExecuteOnMasterCtx(ctx, func(*eal.Lcore) (interface{}, error) {
+		return nil, rule.Destroy()
+	}); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &Empty{}, nil
+}
+
+// Cleanup implements ControlServer.
+func (s *Server) Cleanup(ctx context.Context, _ *Empty) (*Empty, error) {
+	if err := eal.Cleanup(); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &Empty{}, nil
+}
@@ -28,7 +28,10 @@ import "C"
 
 import (
 	"bufio"
+	"context"
+	"fmt"
 	"log"
+	"log/slog"
 	"runtime"
 	"strings"
 	"sync"
@@ -57,13 +60,29 @@ type Lcore struct {
 	// particular lcore.
 	Value interface{}
 
-	// channel to receive functions to execute.
-	ch chan func(*Lcore)
+	// channel to receive jobs to execute.
+	ch chan *lcoreJob
 
 	// signal to kill current thread
 	done bool
 }
 
+// lcoreResult carries the outcome of a job executed on an lcore back to
+// whoever submitted it.
+type lcoreResult struct {
+	value interface{}
+	err   error
+}
+
+// lcoreJob is the envelope sent over an Lcore's channel: a function to
+// run on that lcore's thread and, optionally, a channel to report its
+// result back on. result is nil for fire-and-forget jobs submitted via
+// ExecuteOnLcore.
+type lcoreJob struct {
+	fn     func(*Lcore) (interface{}, error)
+	result chan<- lcoreResult
+}
+
 func err(n ...interface{}) error {
 	if len(n) == 0 {
 		return common.RteErrno()
@@ -96,16 +115,55 @@ type ealConfig struct {
 var (
 	// goEAL is the storage for all EAL lcore threads configuration.
 	goEAL = &ealConfig{make(map[uint]*Lcore)}
+
+	loggerMu sync.RWMutex
+	logger   = slog.Default()
 )
 
-func panicCatcher(fn func(*Lcore), lc *Lcore) {
+// SetLogger replaces the *slog.Logger used for EAL's own structured
+// output: lcore lifecycle events ("lcore started", "lcore exited",
+// "eal cleanup") and recovered panics. Until SetLogger is called,
+// slog.Default() is used.
+func SetLogger(l *slog.Logger) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	logger = l
+}
+
+func getLogger() *slog.Logger {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	return logger
+}
+
+// lcoreAttr is the slog.Attr group attached to every record emitted
+// from lc's thread: lcore_id, socket_id and process_type.
+func lcoreAttr(lc *Lcore) slog.Attr {
+	return slog.Group("eal",
+		slog.Uint64("lcore_id", uint64(lc.ID())),
+		slog.Uint64("socket_id", uint64(lc.SocketID())),
+		slog.Int("process_type", ProcessType()),
+	)
+}
+
+// WithLcore returns a logger scoped to lc, so that every record
+// written through it carries the same lcore_id/socket_id/process_type
+// attributes as eal's own lifecycle and panic records. Call it from
+// inside a fn passed to ExecuteOnLcore/ExecuteOnLcoreCtx, where lc is
+// the *Lcore argument fn receives.
+func WithLcore(lc *Lcore) *slog.Logger {
+	return getLogger().With(lcoreAttr(lc))
+}
+
+// panicCatcher runs fn on lc, recovering from any panic and turning it
+// into an error so that a pending ExecuteOnLcoreCtx caller observes the
+// failure instead of leaving its result channel waiting forever.
+func panicCatcher(fn func(*Lcore) (interface{}, error), lc *Lcore) (val interface{}, err error) {
 	defer func() {
 		r := recover()
 		if r == nil {
 			return
 		}
-		// Report the lcore ID and the panic error
-		log.Printf("panic on lcore %d: %v", lc.ID(), r)
 
 		// this function is called from runtime package, so to
 		// unwind the stack we may skip (1) runtime.Callers
@@ -114,19 +172,25 @@ func panicCatcher(fn func(*Lcore), lc *Lcore) {
 		pc := make([]uintptr, 10)
 		n := runtime.Callers(2, pc)
 		frames := runtime.CallersFrames(pc[:n])
+		var frameList []string
 		for {
 			frame, more := frames.Next()
+			if !strings.HasPrefix(frame.Function, "runtime.") {
+				frameList = append(frameList, fmt.Sprintf("%s:%d %s",
+					frame.File, frame.Line, frame.Function))
+			}
 			if !more {
 				break
 			}
-			if strings.HasPrefix(frame.Function, "runtime.") {
-				continue
-			}
-			log.Printf("... at %s:%d, %s\n", frame.File, frame.Line,
-				frame.Function)
 		}
+
+		getLogger().Error("panic on lcore", lcoreAttr(lc),
+			slog.Any("panic", r),
+			slog.Any("frames", frameList))
+
+		err = fmt.Errorf("panic on lcore %d: %v", lc.ID(), r)
 	}()
-	fn(lc)
+	return fn(lc)
 }
 
 // to run as lcore_function_t
@@ -134,11 +198,14 @@ func panicCatcher(fn func(*Lcore), lc *Lcore) {
 func lcoreFuncListener(unsafe.Pointer) C.int {
 	id := uint(C.rte_lcore_id())
 	lc := goEAL.lcores[id]
-	log.Printf("lcore %d started", id)
-	defer log.Printf("lcore %d exited", id)
+	getLogger().Info("lcore started", lcoreAttr(lc))
+	defer getLogger().Info("lcore exited", lcoreAttr(lc))
 
-	for fn := range lc.ch {
-		panicCatcher(fn, lc)
+	for job := range lc.ch {
+		val, err := panicCatcher(job.fn, lc)
+		if job.result != nil {
+			job.result <- lcoreResult{val, err}
+		}
 		if lc.done {
 			break
 		}
@@ -151,26 +218,36 @@ func lcoreFuncListener(unsafe.Pointer) C.int {
 // executed on some lcores.
 func ealDeInit() error {
 	var e error
-	var wg sync.WaitGroup
-	for _, id := range Lcores(false) {
-		wg.Add(1)
-		ExecuteOnLcore(id, func(lc *Lcore) {
-			defer wg.Done()
-			if lc.done = true; lc.ID() == GetMasterLcore() {
-				e = err(C.rte_eal_cleanup())
-			}
-		})
+	ctx := context.Background()
+	for _, res := range ExecuteOnLcoresCtx(ctx, Lcores(false), func(lc *Lcore) (interface{}, error) {
+		if lc.done = true; lc.ID() == GetMasterLcore() {
+			return nil, err(C.rte_eal_cleanup())
+		}
+		return nil, nil
+	}) {
+		if res.Err != nil {
+			e = res.Err
+		}
 	}
-	wg.Wait()
+
+	if e == nil {
+		getLogger().Info("eal cleanup")
+	}
+
 	return e
 }
 
 // ExecuteOnLcore sends fn to execute on CPU logical core lcoreID, i.e
 // in EAL-owned thread on that lcore. If lcoreID references unknown
-// lcore (i.e. not registered by EAL) the function does nothing.
+// lcore (i.e. not registered by EAL) the function does nothing. fn is
+// fire-and-forget: there is no way to wait for it to finish or to
+// learn whether it panicked. Use ExecuteOnLcoreCtx for that.
 func ExecuteOnLcore(lcoreID uint, fn func(*Lcore)) {
 	if lc, ok := goEAL.lcores[lcoreID]; ok {
-		lc.ch <- fn
+		lc.ch <- &lcoreJob{fn: func(lc *Lcore) (interface{}, error) {
+			fn(lc)
+			return nil, nil
+		}}
 	}
 }
 
@@ -180,6 +257,77 @@ func ExecuteOnMaster(fn func(*Lcore)) {
 	ExecuteOnLcore(GetMasterLcore(), fn)
 }
 
+// ExecuteOnLcoreCtx sends fn to execute on CPU logical core lcoreID and
+// blocks until fn returns, ctx is cancelled, or the lcore is unknown.
+// Unlike ExecuteOnLcore, the value and error returned by fn are
+// propagated back to the caller, and a panic inside fn is recovered
+// and surfaced as err rather than merely logged.
+//
+// If ctx is cancelled before fn could even be handed off to the lcore
+// (e.g. the lcore is stuck processing a previous, long-running job),
+// ExecuteOnLcoreCtx returns ctx.Err() without running fn. If ctx is
+// cancelled after fn has been handed off, fn still runs to completion
+// on the lcore, but ExecuteOnLcoreCtx returns ctx.Err() instead of
+// waiting for its result.
+func ExecuteOnLcoreCtx(ctx context.Context, lcoreID uint, fn func(*Lcore) (interface{}, error)) (interface{}, error) {
+	lc, ok := goEAL.lcores[lcoreID]
+	if !ok {
+		return nil, fmt.Errorf("eal: unknown lcore %d", lcoreID)
+	}
+
+	result := make(chan lcoreResult, 1)
+	job := &lcoreJob{fn: fn, result: result}
+
+	select {
+	case lc.ch <- job:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case res := <-result:
+		return res.value, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ExecuteOnMasterCtx is a shortcut for ExecuteOnLcoreCtx with master
+// lcore as a destination.
+func ExecuteOnMasterCtx(ctx context.Context, fn func(*Lcore) (interface{}, error)) (interface{}, error) {
+	return ExecuteOnLcoreCtx(ctx, GetMasterLcore(), fn)
+}
+
+// LcoreResult holds the outcome of fn executed on a particular lcore
+// via ExecuteOnLcoresCtx.
+type LcoreResult struct {
+	LcoreID uint
+	Value   interface{}
+	Err     error
+}
+
+// ExecuteOnLcoresCtx fans fn out to every lcore in lcoreIDs concurrently
+// and gathers the per-lcore results, preserving the order of lcoreIDs.
+// It waits for all lcores to reply or ctx to be cancelled, whichever
+// happens first; lcores that haven't replied by then report ctx.Err()
+// in their LcoreResult.Err.
+func ExecuteOnLcoresCtx(ctx context.Context, lcoreIDs []uint, fn func(*Lcore) (interface{}, error)) []LcoreResult {
+	out := make([]LcoreResult, len(lcoreIDs))
+
+	var wg sync.WaitGroup
+	wg.Add(len(lcoreIDs))
+	for i, id := range lcoreIDs {
+		go func(i int, id uint) {
+			defer wg.Done()
+			val, err := ExecuteOnLcoreCtx(ctx, id, fn)
+			out[i] = LcoreResult{LcoreID: id, Value: val, Err: err}
+		}(i, id)
+	}
+	wg.Wait()
+
+	return out
+}
+
 type lcoresIter struct {
 	i  C.uint
 	sm C.int
@@ -224,7 +372,7 @@ func ealInitAndLaunch(args []string) error {
 
 	// init per-lcore contexts
 	for _, id := range Lcores(false) {
-		goEAL.lcores[id] = &Lcore{ch: make(chan func(*Lcore))}
+		goEAL.lcores[id] = &Lcore{ch: make(chan *lcoreJob)}
 	}
 
 	// lcore function
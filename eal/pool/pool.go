@@ -0,0 +1,295 @@
+/*
+Package pool layers a NUMA-aware worker pool on top of eal's lcore
+runtime. Where eal.ExecuteOnLcore blocks its caller on a naked,
+unbuffered channel send with no notion of locality, Pool gives each
+managed lcore its own bounded queue, dispatches SubmitOnSocket calls to a
+queue on the requested NUMA socket, and falls back to stealing work
+from other sockets' queues when the local one is saturated.
+
+Each managed lcore is handed, once, a single long-running function via
+eal.ExecuteOnLcore that drains that lcore's queue for as long as the
+Pool is open; SubmitOnSocket and SubmitAny never touch the EAL thread
+themselves, they only push onto a Go channel.
+
+A managed lcore's eal.Lcore is monopolized for as long as the Pool
+that claims it is open: the drain loop is the only reader of that
+lcore's dispatch channel, so any other call that targets it directly
+-- eal.ExecuteOnLcore(Ctx), eal.Cleanup (which iterates every lcore),
+or an ethdev/metrics.Exporter pointed at it -- blocks until Close
+returns. Close before any such call can reach a lcore still owned by
+a Pool. This is also why Config.Lcores defaults to every lcore
+*except* master: master is where most other packages in this module
+schedule their own work by default, and a Pool silently claiming it
+would deadlock them.
+*/
+package pool
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yerden/go-dpdk/eal"
+)
+
+// Task is a unit of work submitted to a Pool. It runs on the lcore
+// that dequeues it, so the same TLS-dependent restrictions as
+// eal.ExecuteOnLcore's fn apply.
+type Task func(*eal.Lcore)
+
+// Config configures a Pool.
+type Config struct {
+	// Lcores is the set of lcores the Pool manages. Defaults to
+	// eal.Lcores(true) (every lcore except master, which other
+	// packages in this module schedule work on by default).
+	Lcores []uint
+
+	// QueueSize bounds each lcore's task queue. Defaults to 256.
+	QueueSize int
+
+	// RebalanceInterval is how often the background monitor checks
+	// for saturated queues to steal from. Defaults to 10ms.
+	RebalanceInterval time.Duration
+}
+
+// lcoreQueue is one managed lcore's bounded MPSC queue plus the
+// counters backing Pool.Stats.
+type lcoreQueue struct {
+	lcoreID uint
+	socket  uint
+	tasks   chan Task
+
+	depth  int64 // atomic: tasks currently queued
+	steals int64 // atomic: tasks moved here from another queue
+}
+
+func (q *lcoreQueue) push(t Task) bool {
+	select {
+	case q.tasks <- t:
+		atomic.AddInt64(&q.depth, 1)
+		return true
+	default:
+		return false
+	}
+}
+
+// QueueStats reports one lcore's queue depth and steal count as of
+// the last Pool.Stats call.
+type QueueStats struct {
+	LcoreID uint
+	Socket  uint
+	Depth   int64
+	Steals  int64
+}
+
+// Pool dispatches Task values to a fixed set of EAL lcores, preferring
+// lcores on the caller's requested NUMA socket and falling back to
+// cross-socket stealing when the local queue is full.
+type Pool struct {
+	queues    []*lcoreQueue
+	bySocket  map[uint][]*lcoreQueue
+	closing   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// New creates a Pool from cfg and starts one worker loop per managed
+// lcore via eal.ExecuteOnLcore. That call hands each loop off over
+// the lcore's unbuffered channel, so New blocks until every managed
+// lcore is free to accept its loop; it waits for nothing beyond that
+// hand-off.
+func New(cfg Config) *Pool {
+	lcores := cfg.Lcores
+	if lcores == nil {
+		lcores = eal.Lcores(true)
+	}
+
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = 256
+	}
+
+	rebalance := cfg.RebalanceInterval
+	if rebalance <= 0 {
+		rebalance = 10 * time.Millisecond
+	}
+
+	p := &Pool{
+		bySocket: make(map[uint][]*lcoreQueue),
+		closing:  make(chan struct{}),
+	}
+
+	for _, id := range lcores {
+		q := &lcoreQueue{
+			lcoreID: id,
+			socket:  eal.LcoreToSocket(id),
+			tasks:   make(chan Task, queueSize),
+		}
+		p.queues = append(p.queues, q)
+		p.bySocket[q.socket] = append(p.bySocket[q.socket], q)
+
+		p.wg.Add(1)
+		eal.ExecuteOnLcore(id, p.drain(q))
+	}
+
+	p.wg.Add(1)
+	go p.rebalanceLoop(rebalance)
+
+	return p
+}
+
+// drain returns the worker function handed to eal.ExecuteOnLcore for
+// q's lcore: it runs for the lifetime of the Pool, pulling tasks off
+// q.tasks and executing them on that lcore's own thread.
+func (p *Pool) drain(q *lcoreQueue) func(*eal.Lcore) {
+	return func(lc *eal.Lcore) {
+		defer p.wg.Done()
+
+		for {
+			select {
+			case t := <-q.tasks:
+				atomic.AddInt64(&q.depth, -1)
+				t(lc)
+			case <-p.closing:
+				return
+			}
+		}
+	}
+}
+
+// rebalanceLoop periodically moves a task from a saturated queue to
+// an idle queue on a different socket, so a burst pinned to one
+// socket doesn't stall behind a full local queue while other lcores
+// sit idle.
+func (p *Pool) rebalanceLoop(interval time.Duration) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.closing:
+			return
+		case <-ticker.C:
+			p.stealOnce()
+		}
+	}
+}
+
+func (p *Pool) stealOnce() {
+	var busiest *lcoreQueue
+	for _, q := range p.queues {
+		depth := atomic.LoadInt64(&q.depth)
+		if busiest == nil || depth > atomic.LoadInt64(&busiest.depth) {
+			busiest = q
+		}
+	}
+
+	if busiest == nil || atomic.LoadInt64(&busiest.depth) < 2 {
+		return
+	}
+
+	// Only look at queues on a different socket than busiest: stealing
+	// within the same socket wouldn't relieve the cross-socket
+	// imbalance this loop exists to fix.
+	var idlest *lcoreQueue
+	for _, q := range p.queues {
+		if q.socket == busiest.socket {
+			continue
+		}
+		depth := atomic.LoadInt64(&q.depth)
+		if idlest == nil || depth < atomic.LoadInt64(&idlest.depth) {
+			idlest = q
+		}
+	}
+
+	// Only steal when the imbalance is large enough to be worth a
+	// cross-socket hop, and never drain the busy queue to empty.
+	if idlest == nil ||
+		atomic.LoadInt64(&busiest.depth)-atomic.LoadInt64(&idlest.depth) < 2 {
+		return
+	}
+
+	select {
+	case t := <-busiest.tasks:
+		atomic.AddInt64(&busiest.depth, -1)
+		if idlest.push(t) {
+			atomic.AddInt64(&idlest.steals, 1)
+		} else {
+			// idlest filled up in the meantime; don't drop the
+			// task, put it back on the queue it came from. Wait
+			// for room there unless the Pool is closing, in which
+			// case queued tasks are discarded anyway.
+			select {
+			case busiest.tasks <- t:
+				atomic.AddInt64(&busiest.depth, 1)
+			case <-p.closing:
+			}
+		}
+	default:
+	}
+}
+
+// SubmitOnSocket queues fn on an lcore local to socket, falling back
+// to the least-loaded lcore on another socket if every local queue is
+// full. It returns an error only if no managed lcore could accept fn.
+func (p *Pool) SubmitOnSocket(socket uint, fn Task) error {
+	for _, q := range p.bySocket[socket] {
+		if q.push(fn) {
+			return nil
+		}
+	}
+	return p.submitLeastLoaded(fn)
+}
+
+// SubmitAny queues fn on the least-loaded managed lcore, regardless of
+// socket.
+func (p *Pool) SubmitAny(fn Task) error {
+	return p.submitLeastLoaded(fn)
+}
+
+func (p *Pool) submitLeastLoaded(fn Task) error {
+	var best *lcoreQueue
+	for _, q := range p.queues {
+		if best == nil || atomic.LoadInt64(&q.depth) < atomic.LoadInt64(&best.depth) {
+			best = q
+		}
+	}
+
+	if best == nil {
+		return fmt.Errorf("pool: no lcores managed")
+	}
+
+	if !best.push(fn) {
+		return fmt.Errorf("pool: lcore %d queue is full", best.lcoreID)
+	}
+
+	return nil
+}
+
+// Stats returns the current queue depth and steal count for every
+// managed lcore.
+func (p *Pool) Stats() []QueueStats {
+	out := make([]QueueStats, len(p.queues))
+	for i, q := range p.queues {
+		out[i] = QueueStats{
+			LcoreID: q.lcoreID,
+			Socket:  q.socket,
+			Depth:   atomic.LoadInt64(&q.depth),
+			Steals:  atomic.LoadInt64(&q.steals),
+		}
+	}
+	return out
+}
+
+// Close stops the rebalance monitor and every lcore's drain loop.
+// Tasks still queued when Close is called are discarded.
+func (p *Pool) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+	})
+	p.wg.Wait()
+	return nil
+}
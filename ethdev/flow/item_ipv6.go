@@ -15,19 +15,11 @@ import (
 	"unsafe"
 )
 
-// IPv4 represents a raw IPv4 address.
+// IPv6 represents a raw IPv6 address.
 type IPv6 [16]byte
 
-// IPv4Header is the IPv4 header raw format.
+// IPv6Header is the IPv6 header raw format.
 type IPv6Header struct {
-	// VersionIHL     uint8  /* Version and header length. */
-	// ToS            uint8  /* Type of service. */
-	// TotalLength    uint16 /* Length of packet. */
-	// ID             uint16 /* Packet ID. */
-	// FragmentOffset uint16 /* Fragmentation offset. */
-	// TTL            uint8  /* Time to live. */
-	// Proto          uint8  /* Protocol ID. */
-	// Checksum       uint16 /* Header checksum. */
 	VtcFlow       uint32 /**< IP version, traffic class & flow label. */
 	PayloadLength uint16 /**< IP packet length - includes header size */
 	Proto         uint8  /* Protocol ID. */
@@ -36,13 +28,17 @@ type IPv6Header struct {
 	DstAddr       IPv6   /* Destination address. */
 }
 
-// ItemIPv4 matches an IPv4 header.
-//
-// Note: IPv4 options are handled by dedicated pattern items.
+// ItemIPv6 matches an IPv6 header.
 type ItemIPv6 struct {
 	cPointer
 
 	Header IPv6Header
+
+	// MaskOverride, if non-nil, is used by Mask instead of the
+	// library's default rte_flow_item_ipv6_mask. Set it to match on
+	// only a subset of Header, e.g. just VtcFlow's flow label or a
+	// /64 SrcAddr prefix.
+	MaskOverride *ItemIPv6Mask
 }
 
 var _ ItemStruct = (*ItemIPv6)(nil)
@@ -55,16 +51,15 @@ func (item *ItemIPv6) Reload() {
 	// runtime.SetFinalizer(item, (*ItemIPv4).free)
 }
 
+// cvtIPv6Header copies every IPv6Header field into dst, an
+// rte_ipv6_hdr laid out in network byte order. VtcFlow and
+// PayloadLength are multi-byte fields and so go through beU32/beU16
+// to convert from src's host byte order.
 func cvtIPv6Header(dst *C.struct_rte_ipv6_hdr, src *IPv6Header) {
-	// setIPv4HdrVersionIHL(dst, src)
-
-	// dst.type_of_service = C.uint8_t(src.ToS)
-	// beU16(src.TotalLength, unsafe.Pointer(&dst.total_length))
-	// beU16(src.ID, unsafe.Pointer(&dst.packet_id))
-	// beU16(src.FragmentOffset, unsafe.Pointer(&dst.fragment_offset))
-	// dst.time_to_live = C.uint8_t(src.TTL)
+	beU32(src.VtcFlow, unsafe.Pointer(&dst.vtc_flow))
+	beU16(src.PayloadLength, unsafe.Pointer(&dst.payload_len))
 	dst.proto = C.uint8_t(src.Proto)
-	// beU16(src.Checksum, unsafe.Pointer(&dst.hdr_checksum))
+	dst.hop_limits = C.uint8_t(src.HopLimits)
 
 	for i := 0; i < 16; i++ {
 		dst.src_addr[i] = (C.uchar)(src.SrcAddr[i])
@@ -72,17 +67,55 @@ func cvtIPv6Header(dst *C.struct_rte_ipv6_hdr, src *IPv6Header) {
 	}
 }
 
-// func setIPv4HdrVersionIHL(dst *C.struct_rte_ipv6_hdr, src *IPv6Header) {
-// 	p := off(unsafe.Pointer(dst), C.IPv4_HDR_OFF_DST_VERSION_IHL)
-// 	*(*C.uint8_t)(p) = (C.uchar)(src.VersionIHL)
-// }
+// beU32 writes v into the 4 bytes at p in network (big-endian) byte
+// order.
+func beU32(v uint32, p unsafe.Pointer) {
+	b := (*[4]byte)(p)
+	b[0] = byte(v >> 24)
+	b[1] = byte(v >> 16)
+	b[2] = byte(v >> 8)
+	b[3] = byte(v)
+}
+
+// beU16 writes v into the 2 bytes at p in network (big-endian) byte
+// order.
+func beU16(v uint16, p unsafe.Pointer) {
+	b := (*[2]byte)(p)
+	b[0] = byte(v >> 8)
+	b[1] = byte(v)
+}
 
 // Type implements ItemStruct interface.
 func (item *ItemIPv6) Type() ItemType {
 	return ItemTypeIPv6
 }
 
-// Mask implements ItemStruct interface.
+// Mask implements ItemStruct interface. It returns the library's
+// default rte_flow_item_ipv6_mask unless MaskOverride is set, in
+// which case the mask built from MaskOverride.Header is returned
+// instead.
 func (item *ItemIPv6) Mask() unsafe.Pointer {
+	if item.MaskOverride != nil {
+		return item.MaskOverride.Pointer()
+	}
 	return unsafe.Pointer(C.get_item_ipv6_mask())
 }
+
+// ItemIPv6Mask is a companion to ItemIPv6 that lets callers override
+// the library's default rte_flow_item_ipv6_mask, which matches the
+// full SrcAddr and DstAddr and no other field of IPv6Header. Set only
+// the fields that should be matched and leave the rest zero.
+type ItemIPv6Mask struct {
+	cPointer
+
+	Header IPv6Header
+}
+
+// Pointer builds the underlying rte_flow_item_ipv6 from Header and
+// returns it as an unsafe.Pointer suitable for use as
+// rte_flow_item.mask.
+func (mask *ItemIPv6Mask) Pointer() unsafe.Pointer {
+	cptr := mask.createOrRet(C.sizeof_struct_rte_flow_item_ipv6)
+	cvtIPv6Header(&(*C.struct_rte_flow_item_ipv6)(cptr).hdr, &mask.Header)
+	return cptr
+}
@@ -0,0 +1,350 @@
+/*
+Package metrics periodically polls ethdev port statistics (Stats and
+Xstat) and exposes them as Prometheus collectors and, optionally,
+OpenTelemetry instruments.
+
+rte_eth_stats_get and rte_eth_xstats_get may only be called from an
+EAL-owned thread, so the poll itself runs on an lcore selected by the
+caller via eal.ExecuteOnLcoreCtx. The poll result is published to a
+lock-free snapshot that ordinary goroutines -- in particular the
+goroutine serving Prometheus scrapes over HTTP -- read without ever
+touching the EAL thread.
+*/
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/yerden/go-dpdk/eal"
+	"github.com/yerden/go-dpdk/ethdev"
+)
+
+// portSnapshot is one port's worth of polled statistics plus
+// everything a scrape needs to label it.
+type portSnapshot struct {
+	port   ethdev.PortID
+	socket int
+	driver string
+	pci    string
+
+	stats      ethdev.EthStats
+	xstats     []ethdev.Xstat
+	xstatNames []string
+}
+
+// Config controls which ports an Exporter polls, where the poll runs
+// and how often.
+type Config struct {
+	// Ports is the set of ports to poll. A nil slice polls every
+	// port known to ethdev.Ports().
+	Ports []ethdev.PortID
+
+	// Lcore is the lcore the poll is scheduled on via
+	// eal.ExecuteOnLcoreCtx. Zero (the default) means the master
+	// lcore, same as eal.GetMasterLcore().
+	Lcore uint
+
+	// Interval is how often Ports are polled. Defaults to one
+	// second.
+	Interval time.Duration
+
+	// Meter, if non-nil, additionally registers the collected
+	// counters and gauges as OpenTelemetry instruments on this
+	// meter.
+	Meter metric.Meter
+}
+
+// Exporter polls a set of ethdev ports on a schedule and serves their
+// Stats and Xstat as Prometheus metrics. It implements
+// prometheus.Collector, so it may be registered directly with a
+// prometheus.Registry.
+type Exporter struct {
+	cfg Config
+
+	// snapshot holds the most recent []portSnapshot. It is written
+	// only by the poll loop and read by Collect, so a plain
+	// atomic.Value gives every scrape a consistent view without
+	// blocking the poller.
+	snapshot atomic.Value
+
+	ipackets *prometheus.Desc
+	opackets *prometheus.Desc
+	ibytes   *prometheus.Desc
+	obytes   *prometheus.Desc
+	ierrors  *prometheus.Desc
+	oerrors  *prometheus.Desc
+	imissed  *prometheus.Desc
+	rxNoMbuf *prometheus.Desc
+	xstat    *prometheus.Desc
+}
+
+// NewExporter creates an Exporter for cfg and, if cfg.Meter is set,
+// registers the corresponding OpenTelemetry instruments on it. Call
+// Run to start polling.
+func NewExporter(cfg Config) (*Exporter, error) {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Second
+	}
+	if cfg.Lcore == 0 {
+		cfg.Lcore = eal.GetMasterLcore()
+	}
+
+	labels := []string{"port", "socket", "driver", "pci"}
+	e := &Exporter{
+		cfg: cfg,
+		ipackets: prometheus.NewDesc("dpdk_ethdev_ipackets_total",
+			"Successfully received packets.", labels, nil),
+		opackets: prometheus.NewDesc("dpdk_ethdev_opackets_total",
+			"Successfully transmitted packets.", labels, nil),
+		ibytes: prometheus.NewDesc("dpdk_ethdev_ibytes_total",
+			"Successfully received bytes.", labels, nil),
+		obytes: prometheus.NewDesc("dpdk_ethdev_obytes_total",
+			"Successfully transmitted bytes.", labels, nil),
+		ierrors: prometheus.NewDesc("dpdk_ethdev_ierrors_total",
+			"Erroneous received packets.", labels, nil),
+		oerrors: prometheus.NewDesc("dpdk_ethdev_oerrors_total",
+			"Erroneous transmitted packets.", labels, nil),
+		imissed: prometheus.NewDesc("dpdk_ethdev_imissed_total",
+			"Packets dropped by the HW receive queues.", labels, nil),
+		rxNoMbuf: prometheus.NewDesc("dpdk_ethdev_rx_nombuf_total",
+			"Times the HW ran out of receive buffers.", labels, nil),
+		xstat: prometheus.NewDesc("dpdk_ethdev_xstat",
+			"Driver-specific extended statistic.",
+			append(append([]string{}, labels...), "xstat"), nil),
+	}
+	e.snapshot.Store([]portSnapshot(nil))
+
+	if cfg.Meter != nil {
+		if err := e.registerOtel(cfg.Meter); err != nil {
+			return nil, fmt.Errorf("metrics: registering OpenTelemetry instruments: %w", err)
+		}
+	}
+
+	return e, nil
+}
+
+// registerOtel creates an observable instrument per Stats/Xstat field
+// and a single callback that, on every OpenTelemetry collection,
+// reads whatever snapshot the poll loop last published -- the same
+// snapshot Collect reads for Prometheus.
+func (e *Exporter) registerOtel(meter metric.Meter) error {
+	ipackets, err := meter.Int64ObservableCounter("dpdk.ethdev.ipackets")
+	if err != nil {
+		return err
+	}
+	opackets, err := meter.Int64ObservableCounter("dpdk.ethdev.opackets")
+	if err != nil {
+		return err
+	}
+	ibytes, err := meter.Int64ObservableCounter("dpdk.ethdev.ibytes")
+	if err != nil {
+		return err
+	}
+	obytes, err := meter.Int64ObservableCounter("dpdk.ethdev.obytes")
+	if err != nil {
+		return err
+	}
+	ierrors, err := meter.Int64ObservableCounter("dpdk.ethdev.ierrors")
+	if err != nil {
+		return err
+	}
+	oerrors, err := meter.Int64ObservableCounter("dpdk.ethdev.oerrors")
+	if err != nil {
+		return err
+	}
+	imissed, err := meter.Int64ObservableCounter("dpdk.ethdev.imissed")
+	if err != nil {
+		return err
+	}
+	rxNoMbuf, err := meter.Int64ObservableCounter("dpdk.ethdev.rx_nombuf")
+	if err != nil {
+		return err
+	}
+	xstat, err := meter.Float64ObservableGauge("dpdk.ethdev.xstat")
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		snaps, _ := e.snapshot.Load().([]portSnapshot)
+		for _, s := range snaps {
+			attrs := metric.WithAttributes(
+				attribute.String("port", fmt.Sprint(s.port)),
+				attribute.Int64("socket", int64(s.socket)),
+				attribute.String("driver", s.driver),
+				attribute.String("pci", s.pci),
+			)
+
+			o.ObserveInt64(ipackets, int64(s.stats.Ipackets), attrs)
+			o.ObserveInt64(opackets, int64(s.stats.Opackets), attrs)
+			o.ObserveInt64(ibytes, int64(s.stats.Ibytes), attrs)
+			o.ObserveInt64(obytes, int64(s.stats.Obytes), attrs)
+			o.ObserveInt64(ierrors, int64(s.stats.Ierrors), attrs)
+			o.ObserveInt64(oerrors, int64(s.stats.Oerrors), attrs)
+			o.ObserveInt64(imissed, int64(s.stats.Imissed), attrs)
+			o.ObserveInt64(rxNoMbuf, int64(s.stats.RxNoMbuf), attrs)
+
+			for _, x := range s.xstats {
+				name := fmt.Sprintf("%d", x.Index)
+				if int(x.Index) < len(s.xstatNames) {
+					name = s.xstatNames[x.Index]
+				}
+				o.ObserveFloat64(xstat, float64(x.Value), attrs,
+					metric.WithAttributes(attribute.String("xstat", name)))
+			}
+		}
+		return nil
+	}, ipackets, opackets, ibytes, obytes, ierrors, oerrors, imissed, rxNoMbuf, xstat)
+
+	return err
+}
+
+// Handler returns an http.Handler serving e's metrics in the
+// Prometheus exposition format. It may be mounted on a plain
+// net/http.ServeMux and served from a normal goroutine -- scraping
+// never touches the EAL thread, it only reads the snapshot published
+// by Run.
+func (e *Exporter) Handler() http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(e)
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
+
+// Describe implements prometheus.Collector.
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.ipackets
+	ch <- e.opackets
+	ch <- e.ibytes
+	ch <- e.obytes
+	ch <- e.ierrors
+	ch <- e.oerrors
+	ch <- e.imissed
+	ch <- e.rxNoMbuf
+	ch <- e.xstat
+}
+
+// Collect implements prometheus.Collector. It never touches the EAL
+// thread: it only reads the snapshot published by the poll loop.
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	snaps, _ := e.snapshot.Load().([]portSnapshot)
+	for _, s := range snaps {
+		labels := []string{fmt.Sprint(s.port), fmt.Sprint(s.socket), s.driver, s.pci}
+
+		ch <- prometheus.MustNewConstMetric(e.ipackets, prometheus.CounterValue, float64(s.stats.Ipackets), labels...)
+		ch <- prometheus.MustNewConstMetric(e.opackets, prometheus.CounterValue, float64(s.stats.Opackets), labels...)
+		ch <- prometheus.MustNewConstMetric(e.ibytes, prometheus.CounterValue, float64(s.stats.Ibytes), labels...)
+		ch <- prometheus.MustNewConstMetric(e.obytes, prometheus.CounterValue, float64(s.stats.Obytes), labels...)
+		ch <- prometheus.MustNewConstMetric(e.ierrors, prometheus.CounterValue, float64(s.stats.Ierrors), labels...)
+		ch <- prometheus.MustNewConstMetric(e.oerrors, prometheus.CounterValue, float64(s.stats.Oerrors), labels...)
+		ch <- prometheus.MustNewConstMetric(e.imissed, prometheus.CounterValue, float64(s.stats.Imissed), labels...)
+		ch <- prometheus.MustNewConstMetric(e.rxNoMbuf, prometheus.CounterValue, float64(s.stats.RxNoMbuf), labels...)
+
+		for _, x := range s.xstats {
+			name := fmt.Sprintf("%d", x.Index)
+			if int(x.Index) < len(s.xstatNames) {
+				name = s.xstatNames[x.Index]
+			}
+			xlabels := append(append([]string{}, labels...), name)
+			ch <- prometheus.MustNewConstMetric(e.xstat, prometheus.GaugeValue, float64(x.Value), xlabels...)
+		}
+	}
+}
+
+// Run polls Config.Ports on Config.Interval until ctx is cancelled.
+// Each poll is scheduled on Config.Lcore via eal.ExecuteOnLcoreCtx, so
+// Run itself may be called from any goroutine. Run blocks until ctx is
+// done or a poll round itself fails (for example, Config.Lcore is not
+// a known lcore), and returns that error; a single port failing a poll
+// is logged and skipped rather than ending collection for every port.
+func (e *Exporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := e.poll(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// poll runs one collection round on Config.Lcore and publishes the
+// result to the snapshot buffer read by both Collect and the
+// OpenTelemetry callback registered in registerOtel. A port that fails
+// to report is logged and left out of the published snapshot; it
+// doesn't stop the round or count as an error for the caller.
+func (e *Exporter) poll(ctx context.Context) error {
+	ports := e.cfg.Ports
+	if ports == nil {
+		ports = ethdev.Ports()
+	}
+
+	val, err := eal.ExecuteOnLcoreCtx(ctx, e.cfg.Lcore, func(*eal.Lcore) (interface{}, error) {
+		snaps := make([]portSnapshot, 0, len(ports))
+		for _, port := range ports {
+			snap, err := pollPort(port)
+			if err != nil {
+				slog.Default().Warn("metrics: skipping port after poll error",
+					slog.Any("port", port), slog.Any("error", err))
+				continue
+			}
+			snaps = append(snaps, snap)
+		}
+		return snaps, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	snaps := val.([]portSnapshot)
+	e.snapshot.Store(snaps)
+	return nil
+}
+
+// pollPort collects one port's Stats, Xstat and device info. It must
+// run on the EAL thread poll schedules its caller on.
+func pollPort(port ethdev.PortID) (portSnapshot, error) {
+	stats, err := port.Stats()
+	if err != nil {
+		return portSnapshot{}, fmt.Errorf("metrics: port %d: %w", port, err)
+	}
+
+	xstats, err := port.Xstats()
+	if err != nil {
+		return portSnapshot{}, fmt.Errorf("metrics: port %d: %w", port, err)
+	}
+
+	names, err := ethdev.XstatNames(port)
+	if err != nil {
+		return portSnapshot{}, fmt.Errorf("metrics: port %d: %w", port, err)
+	}
+
+	info, err := port.DevInfo()
+	if err != nil {
+		return portSnapshot{}, fmt.Errorf("metrics: port %d: %w", port, err)
+	}
+
+	return portSnapshot{
+		port:       port,
+		socket:     port.SocketID(),
+		driver:     info.DriverName,
+		pci:        info.PCIAddress,
+		stats:      stats,
+		xstats:     xstats,
+		xstatNames: names,
+	}, nil
+}